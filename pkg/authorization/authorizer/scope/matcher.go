@@ -0,0 +1,71 @@
+package scope
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/openshift/origin/pkg/oauth/api"
+)
+
+// ClientScopeMatcher is a compiled form of an OAuthClient's ScopeRestrictions.
+// Compile it once per client (e.g. when the client is loaded) and reuse the
+// result for every token-issue scope check, instead of walking
+// ScopeRestrictions and re-validating each requested scope on every request.
+type ClientScopeMatcher struct {
+	exactValues  []string // kept sorted, matched with sort.SearchStrings
+	regexes      []*regexp.Regexp
+	clusterRoles []*api.ClusterRoleScopeRestriction
+}
+
+// CompileClientScopeMatcher validates and compiles restrictions into a
+// ClientScopeMatcher. It returns an error if any Regex restriction fails to
+// compile as RE2.
+func CompileClientScopeMatcher(restrictions []api.ScopeRestriction) (*ClientScopeMatcher, error) {
+	m := &ClientScopeMatcher{}
+	for _, restriction := range restrictions {
+		switch {
+		case len(restriction.ExactValues) > 0:
+			m.exactValues = append(m.exactValues, restriction.ExactValues...)
+
+		case len(restriction.Regex) > 0:
+			if _, err := regexp.Compile(restriction.Regex); err != nil {
+				return nil, fmt.Errorf("invalid scope restriction regex %q: %v", restriction.Regex, err)
+			}
+			// Anchor the expression so a scope must match it in full.
+			re, err := regexp.Compile(`^(?:` + restriction.Regex + `)$`)
+			if err != nil {
+				return nil, fmt.Errorf("invalid scope restriction regex %q: %v", restriction.Regex, err)
+			}
+			m.regexes = append(m.regexes, re)
+
+		case restriction.ClusterRole != nil:
+			m.clusterRoles = append(m.clusterRoles, restriction.ClusterRole)
+		}
+	}
+	sort.Strings(m.exactValues)
+	return m, nil
+}
+
+// AllowsExactly reports whether scope is permitted by one of the compiled
+// exact-value or regex restrictions, in O(log n) and O(len(regexes)) time
+// respectively. ClusterRole restrictions require a live role-binding lookup
+// and are not evaluated here; see ClusterRoles.
+func (m *ClientScopeMatcher) AllowsExactly(scope string) bool {
+	if i := sort.SearchStrings(m.exactValues, scope); i < len(m.exactValues) && m.exactValues[i] == scope {
+		return true
+	}
+	for _, re := range m.regexes {
+		if re.MatchString(scope) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClusterRoles returns the cluster-role restrictions that AllowsExactly
+// cannot itself evaluate, for the caller to check against the live RBAC
+// role bindings at token-issue time.
+func (m *ClientScopeMatcher) ClusterRoles() []*api.ClusterRoleScopeRestriction {
+	return m.clusterRoles
+}
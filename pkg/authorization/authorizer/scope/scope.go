@@ -0,0 +1,59 @@
+// Package scope interprets OAuth scope strings into the privileges they
+// grant. Each kind of scope (user info, cluster role, ...) is handled by a
+// ScopeEvaluator registered in ScopeEvaluators.
+package scope
+
+import "fmt"
+
+// ParsedScope is the structured form of a scope string, produced by a
+// ScopeEvaluator's Parse method. Validation errors can then name exactly
+// which component was wrong instead of rejecting the scope wholesale.
+type ParsedScope struct {
+	// Handler is the name of the ScopeEvaluator that owns this scope, e.g. "role".
+	Handler string
+	// Verb is the action component of the scope, when the handler's grammar has one.
+	Verb string
+	// Resource is the resource component of the scope, when the handler's grammar has one.
+	Resource string
+	// Namespace is the namespace component of the scope, or "" for cluster-scoped handlers.
+	Namespace string
+	// Params holds any remaining handler-specific key/value components.
+	Params map[string]string
+}
+
+// ScopeEvaluator interprets scopes for a single handler prefix (e.g. "role",
+// "user"). Implementations register themselves in ScopeEvaluators.
+type ScopeEvaluator interface {
+	// Handles returns true if this evaluator owns the given scope string.
+	Handles(scope string) bool
+	// Validate returns an error describing why scope is not well-formed or
+	// not grantable, or nil if it is acceptable.
+	Validate(scope string) error
+	// Parse decomposes scope into a ParsedScope, or returns an error naming
+	// the specific component that failed to parse.
+	Parse(scope string) (ParsedScope, error)
+}
+
+// ScopeEvaluators holds the registered evaluators, consulted in order by
+// ValidateScopes and the OAuth grant handler. Evaluators register themselves
+// via RegisterScopeEvaluator, typically from an init() function in the file
+// that implements their grammar.
+var ScopeEvaluators []ScopeEvaluator
+
+// RegisterScopeEvaluator adds evaluator to ScopeEvaluators.
+func RegisterScopeEvaluator(evaluator ScopeEvaluator) {
+	ScopeEvaluators = append(ScopeEvaluators, evaluator)
+}
+
+// ParseScope walks ScopeEvaluators and returns the ParsedScope from the
+// first evaluator that handles it, or an error if no evaluator claims it or
+// the owning evaluator rejects it.
+func ParseScope(scope string) (ParsedScope, error) {
+	for _, evaluator := range ScopeEvaluators {
+		if !evaluator.Handles(scope) {
+			continue
+		}
+		return evaluator.Parse(scope)
+	}
+	return ParsedScope{}, fmt.Errorf("no scope handler found for %q", scope)
+}
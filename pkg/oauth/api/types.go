@@ -0,0 +1,184 @@
+package api
+
+import (
+	kapi "k8s.io/kubernetes/pkg/api"
+)
+
+// OAuthAccessToken describes an OAuth access token.
+type OAuthAccessToken struct {
+	kapi.TypeMeta
+	kapi.ObjectMeta
+
+	// ClientName references the client that created this token.
+	ClientName string
+
+	// UserName is the user name associated with this token.
+	UserName string
+	// UserUID is the unique UID associated with this token. UserUID and UserName must both match for this token to be valid.
+	UserUID string
+
+	// AuthorizeToken contains the token that authorized this token.
+	AuthorizeToken string
+	// RefreshToken is the value by which this token can be renewed. Can be blank.
+	RefreshToken string
+
+	// Scopes is an array of the requested scopes.
+	Scopes []string
+
+	// RedirectURI is the redirection associated with the token.
+	RedirectURI string
+}
+
+// OAuthAuthorizeToken describes an OAuth authorization token.
+type OAuthAuthorizeToken struct {
+	kapi.TypeMeta
+	kapi.ObjectMeta
+
+	// ClientName references the client that created this token.
+	ClientName string
+
+	// UserName is the user name associated with this token.
+	UserName string
+	// UserUID is the unique UID associated with this token.
+	UserUID string
+
+	// Scopes is an array of the requested scopes.
+	Scopes []string
+
+	// RedirectURI is the redirection associated with the token.
+	RedirectURI string
+	// State data from request.
+	State string
+
+	// CodeChallenge is the optional code_challenge associated with this authorization code, as described in RFC7636.
+	CodeChallenge string
+	// CodeChallengeMethod is the optional code_challenge_method associated with this authorization code, as described in RFC7636.
+	CodeChallengeMethod string
+}
+
+// OAuthClient describes an OAuth client.
+type OAuthClient struct {
+	kapi.TypeMeta
+	kapi.ObjectMeta
+
+	// Secret is the unique secret associated with a client.
+	Secret string
+	// AdditionalSecrets holds other secrets that may be used to identify the client. This is useful for rotation
+	// and for service accounts, which can generate new secrets if the value of the secret changes.
+	AdditionalSecrets []string
+
+	// RespondWithChallenges indicates whether the client wants authentication needed responses made in the form of challenges instead of redirects.
+	RespondWithChallenges bool
+
+	// RedirectURIs is the valid redirection URIs associated with a client.
+	RedirectURIs []string
+
+	// AllowInsecureRedirects permits http:// RedirectURIs entries for hosts other than
+	// a loopback address (127.0.0.1, ::1, localhost). Operators must opt in explicitly
+	// so that a plaintext callback is never accepted by accident.
+	AllowInsecureRedirects bool
+
+	// GrantMethod determines how to handle grants for this client. If no method is provided, the
+	// cluster default grant handling method will be used.
+	GrantMethod GrantHandlerType
+
+	// ScopeRestrictions describes which scopes this client can request. Each requested scope
+	// is checked against each restriction. If any restriction matches, then the scope is allowed.
+	// If no restriction matches, then the scope is denied.
+	ScopeRestrictions []ScopeRestriction
+
+	// RequestObjectSigningAlg is the JWS algorithm (RS256, ES256, or PS256) this client's
+	// RFC 9101 signed request objects must be signed with. Empty means the client does not
+	// use request objects.
+	RequestObjectSigningAlg string
+	// JWKSURI is an absolute https URL at which this client publishes its public keys. Signed
+	// request objects are verified only against JWKS; JWKSURI is not fetched for that.
+	// At most one of JWKSURI and JWKS may be set.
+	JWKSURI string
+	// JWKS is an inline JSON Web Key Set of this client's public keys, for clients that cannot
+	// host a JWKSURI. At most one of JWKSURI and JWKS may be set.
+	JWKS string
+}
+
+// GrantHandlerType determines what type of update to perform on grant.
+type GrantHandlerType string
+
+const (
+	// GrantHandlerAuto auto-approves client authorization grant requests.
+	GrantHandlerAuto GrantHandlerType = "auto"
+	// GrantHandlerPrompt prompts the user to approve new client authorization grant requests.
+	GrantHandlerPrompt GrantHandlerType = "prompt"
+	// GrantHandlerDeny auto-denies client authorization grant requests.
+	GrantHandlerDeny GrantHandlerType = "deny"
+)
+
+// ScopeRestriction describes one restriction on scopes. Exactly one option must be non-nil/non-empty.
+type ScopeRestriction struct {
+	// ExactValues means the scope has to match a particular set of strings exactly
+	ExactValues []string
+
+	// ClusterRole describes a set of restrictions for cluster role scoping.
+	ClusterRole *ClusterRoleScopeRestriction
+
+	// Regex is an RE2 regular expression that a requested scope must fully match to be allowed.
+	Regex string
+}
+
+// ClusterRoleScopeRestriction describes restrictions on cluster role scopes.
+type ClusterRoleScopeRestriction struct {
+	// RoleNames is the list of cluster roles that can be referenced. * means anything.
+	RoleNames []string
+	// Namespaces is the list of namespaces that can be referenced. * means any of them (including *all* cluster-scoped resources).
+	Namespaces []string
+	// AllowEscalation allows you to escalate to a cluster role that can escalate.
+	AllowEscalation bool
+}
+
+// OAuthDeviceCode describes an in-progress RFC 8628 device authorization grant.
+type OAuthDeviceCode struct {
+	kapi.TypeMeta
+	kapi.ObjectMeta
+
+	// ClientName references the client that initiated the device flow.
+	ClientName string
+
+	// DeviceCode is the long-lived code the device polls the token endpoint with.
+	DeviceCode string
+	// UserCode is the short code displayed to the user and entered at the verification URI.
+	UserCode string
+
+	// Scopes is an array of the requested scopes.
+	Scopes []string
+
+	// ExpiresIn is the lifetime, in seconds, of the device and user codes.
+	ExpiresIn int64
+	// Interval is the minimum number of seconds the client must wait between polling requests.
+	Interval int64
+
+	// Approved is true once a signed-in user has bound their identity to this device code.
+	Approved bool
+	// UserName is the user that approved this device code. Only meaningful once Approved is true.
+	UserName string
+	// UserUID is the unique UID of the user that approved this device code.
+	UserUID string
+
+	// RedirectURI is an optional redirect used by browser-based verification flows.
+	RedirectURI string
+}
+
+// OAuthClientAuthorization describes an authorization created by a user for a specific client.
+type OAuthClientAuthorization struct {
+	kapi.TypeMeta
+	kapi.ObjectMeta
+
+	// ClientName references the client that created this authorization.
+	ClientName string
+
+	// UserName is the user name that authorized the client.
+	UserName string
+	// UserUID is the unique UID associated with this authorization. UserUID and UserName must both match for this authorization to be valid.
+	UserUID string
+
+	// Scopes is an array of the granted scopes.
+	Scopes []string
+}
@@ -0,0 +1,99 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+
+	"k8s.io/kubernetes/pkg/api/validation"
+	"k8s.io/kubernetes/pkg/util/validation/field"
+
+	"github.com/openshift/origin/pkg/oauth/api"
+)
+
+// MinDeviceCodePollInterval is the minimum number of seconds, per RFC 8628
+// section 3.2, that a client must wait between device token polls.
+const MinDeviceCodePollInterval = 5
+
+// userCodeAlphabet excludes vowels and visually ambiguous letters (I, O, etc.)
+// so a user transcribing the code from one screen to another is unlikely to
+// make a mistake. https://tools.ietf.org/html/rfc8628#section-6.1
+const userCodeAlphabet = "BCDFGHJKLMNPQRSTVWXZ"
+
+const userCodeLength = 8
+
+var userCodeRegex = regexp.MustCompile(fmt.Sprintf("^[%s]{%d}$", userCodeAlphabet, userCodeLength))
+
+// ValidateDeviceCode validates a new OAuthDeviceCode.
+func ValidateDeviceCode(deviceCode *api.OAuthDeviceCode) field.ErrorList {
+	allErrs := validation.ValidateObjectMeta(&deviceCode.ObjectMeta, false, ValidateTokenName, field.NewPath("metadata"))
+	allErrs = append(allErrs, ValidateClientNameField(deviceCode.ClientName, field.NewPath("clientName"))...)
+	allErrs = append(allErrs, ValidateScopes(deviceCode.Scopes, field.NewPath("scopes"))...)
+
+	if reasons := ValidateTokenName(deviceCode.DeviceCode, false); len(reasons) != 0 {
+		for _, reason := range reasons {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("deviceCode"), deviceCode.DeviceCode, reason))
+		}
+	}
+	if !userCodeRegex.MatchString(deviceCode.UserCode) {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("userCode"), deviceCode.UserCode, fmt.Sprintf("must be %d characters from the unambiguous alphabet [%s]", userCodeLength, userCodeAlphabet)))
+	}
+	if deviceCode.Interval < MinDeviceCodePollInterval {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("interval"), deviceCode.Interval, fmt.Sprintf("must be at least %d seconds", MinDeviceCodePollInterval)))
+	}
+	if deviceCode.ExpiresIn <= 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("expiresIn"), deviceCode.ExpiresIn, "must be greater than zero"))
+	}
+	if len(deviceCode.RedirectURI) > 0 {
+		if ok, msg := ValidateRedirectURI(deviceCode.RedirectURI); !ok {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("redirectURI"), deviceCode.RedirectURI, msg))
+		}
+	}
+
+	if deviceCode.Approved {
+		allErrs = append(allErrs, ValidateUserNameField(deviceCode.UserName, field.NewPath("userName"))...)
+		if len(deviceCode.UserUID) == 0 {
+			allErrs = append(allErrs, field.Required(field.NewPath("userUID"), "required once approved"))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateDeviceCodeUpdate validates an update to an OAuthDeviceCode. The
+// only legal transition is binding an approving user's identity to a
+// previously unapproved code; every other field is immutable.
+func ValidateDeviceCodeUpdate(newCode, oldCode *api.OAuthDeviceCode) field.ErrorList {
+	allErrs := ValidateDeviceCode(newCode)
+	allErrs = append(allErrs, validation.ValidateObjectMetaUpdate(&newCode.ObjectMeta, &oldCode.ObjectMeta, field.NewPath("metadata"))...)
+
+	if newCode.ClientName != oldCode.ClientName {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("clientName"), newCode.ClientName, "clientName is not a mutable field"))
+	}
+	if newCode.DeviceCode != oldCode.DeviceCode {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("deviceCode"), newCode.DeviceCode, "deviceCode is not a mutable field"))
+	}
+	if newCode.UserCode != oldCode.UserCode {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("userCode"), newCode.UserCode, "userCode is not a mutable field"))
+	}
+	if !reflect.DeepEqual(newCode.Scopes, oldCode.Scopes) {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("scopes"), newCode.Scopes, "scopes is not a mutable field"))
+	}
+	if newCode.ExpiresIn != oldCode.ExpiresIn {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("expiresIn"), newCode.ExpiresIn, "expiresIn is not a mutable field"))
+	}
+	if newCode.Interval != oldCode.Interval {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("interval"), newCode.Interval, "interval is not a mutable field"))
+	}
+	if newCode.RedirectURI != oldCode.RedirectURI {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("redirectURI"), newCode.RedirectURI, "redirectURI is not a mutable field"))
+	}
+	if oldCode.Approved && !newCode.Approved {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("approved"), newCode.Approved, "cannot unapprove a device code"))
+	}
+	if oldCode.Approved && (newCode.UserName != oldCode.UserName || newCode.UserUID != oldCode.UserUID) {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("userName"), newCode.UserName, "cannot rebind the user of an approved device code"))
+	}
+
+	return allErrs
+}
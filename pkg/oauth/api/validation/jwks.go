@@ -0,0 +1,134 @@
+package validation
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"k8s.io/kubernetes/pkg/util/validation/field"
+)
+
+// jsonWebKeySet is the subset of RFC 7517 needed to validate and consume an
+// OAuthClient's inline JWKS.
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+
+	// RSA public key components.
+	N string `json:"n"`
+	E string `json:"e"`
+
+	// EC public key components.
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func decodeJWKS(raw string) (jsonWebKeySet, error) {
+	var jwks jsonWebKeySet
+	if err := json.Unmarshal([]byte(raw), &jwks); err != nil {
+		return jsonWebKeySet{}, err
+	}
+	return jwks, nil
+}
+
+// validateJWKS checks that raw is a well-formed JSON Web Key Set containing
+// at least one signing key, each with a kid and use=sig.
+func validateJWKS(raw string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	jwks, err := decodeJWKS(raw)
+	if err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath, raw, fmt.Sprintf("must be a valid JSON Web Key Set: %v", err)))
+		return allErrs
+	}
+	if len(jwks.Keys) == 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath, raw, "must contain at least one key"))
+	}
+	for i, key := range jwks.Keys {
+		if len(key.Kid) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("keys").Index(i).Child("kid"), ""))
+		}
+		if key.Use != "sig" {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("keys").Index(i).Child("use"), key.Use, []string{"sig"}))
+		}
+	}
+
+	return allErrs
+}
+
+// parseJWKSKeys decodes raw into a map of kid to public key, for use as a
+// jwt.Keyfunc's result when verifying a signed request object.
+func parseJWKSKeys(raw string) (map[string]interface{}, error) {
+	jwks, err := decodeJWKS(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON Web Key Set: %v", err)
+	}
+
+	keys := map[string]interface{}{}
+	for _, key := range jwks.Keys {
+		if len(key.Kid) == 0 {
+			continue
+		}
+		switch key.Kty {
+		case "RSA":
+			pub, err := rsaPublicKeyFromJWK(key)
+			if err != nil {
+				return nil, fmt.Errorf("key %q: %v", key.Kid, err)
+			}
+			keys[key.Kid] = pub
+		case "EC":
+			pub, err := ecPublicKeyFromJWK(key)
+			if err != nil {
+				return nil, fmt.Errorf("key %q: %v", key.Kid, err)
+			}
+			keys[key.Kid] = pub
+		default:
+			return nil, fmt.Errorf("key %q: unsupported kty %q", key.Kid, key.Kty)
+		}
+	}
+	return keys, nil
+}
+
+func rsaPublicKeyFromJWK(key jsonWebKey) (*rsa.PublicKey, error) {
+	nBytes, err := base64.RawURLEncoding.DecodeString(key.N)
+	if err != nil {
+		return nil, fmt.Errorf("invalid n: %v", err)
+	}
+	eBytes, err := base64.RawURLEncoding.DecodeString(key.E)
+	if err != nil {
+		return nil, fmt.Errorf("invalid e: %v", err)
+	}
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(nBytes),
+		E: int(new(big.Int).SetBytes(eBytes).Int64()),
+	}, nil
+}
+
+func ecPublicKeyFromJWK(key jsonWebKey) (*ecdsa.PublicKey, error) {
+	if key.Crv != "P-256" {
+		return nil, fmt.Errorf("unsupported crv %q", key.Crv)
+	}
+	xBytes, err := base64.RawURLEncoding.DecodeString(key.X)
+	if err != nil {
+		return nil, fmt.Errorf("invalid x: %v", err)
+	}
+	yBytes, err := base64.RawURLEncoding.DecodeString(key.Y)
+	if err != nil {
+		return nil, fmt.Errorf("invalid y: %v", err)
+	}
+	return &ecdsa.PublicKey{
+		Curve: elliptic.P256(),
+		X:     new(big.Int).SetBytes(xBytes),
+		Y:     new(big.Int).SetBytes(yBytes),
+	}, nil
+}
@@ -0,0 +1,63 @@
+package validation
+
+import "testing"
+
+func TestPKCEMethodVerify(t *testing.T) {
+	// base64url(sha256("test-verifier-1234567890123456789012"))
+	s256Verifier := "test-verifier-1234567890123456789012"
+	s256Challenge := "mybij_T1kc_2rrA8ICOAwcm86-R08zdB6G_6KH0Hmh0"
+	// base64url(sha512("test-verifier-1234567890123456789012"))
+	s512Verifier := s256Verifier
+	s512Challenge := "UjIB6Q3utVMTod8oURS5C7vaOK0KOMqAkmXyv8X7n597-zFIv0e3_LAvXMqksXEq-9EnGkwCImioO0t-M3Bq-g"
+
+	tests := []struct {
+		name      string
+		method    PKCEMethod
+		verifier  string
+		challenge string
+		want      bool
+	}{
+		{"plain match", plainPKCEMethod{}, "abc123", "abc123", true},
+		{"plain mismatch", plainPKCEMethod{}, "abc123", "abc456", false},
+		{"plain empty verifier", plainPKCEMethod{}, "", "abc123", false},
+		{"S256 match", s256PKCEMethod{}, s256Verifier, s256Challenge, true},
+		{"S256 mismatch", s256PKCEMethod{}, s256Verifier, "wrong-challenge-wrong-challenge-wrong-chal", false},
+		{"S256 wrong verifier", s256PKCEMethod{}, "not-the-verifier", s256Challenge, false},
+		{"S512 match", s512PKCEMethod{}, s512Verifier, s512Challenge, true},
+		{"S512 mismatch", s512PKCEMethod{}, s512Verifier, s256Challenge, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.method.Verify(tt.verifier, tt.challenge); got != tt.want {
+				t.Errorf("%s.Verify(%q, %q) = %v, want %v", tt.method.Name(), tt.verifier, tt.challenge, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPKCEMethodValidateChallenge(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    PKCEMethod
+		challenge string
+		wantErr   bool
+	}{
+		{"plain valid", plainPKCEMethod{}, "0123456789012345678901234567890123456789012", false},
+		{"plain too short", plainPKCEMethod{}, "short", true},
+		{"plain bad charset", plainPKCEMethod{}, "0123456789012345678901234567890123456789!!!", true},
+		{"S256 valid length", s256PKCEMethod{}, "mybij_T1kc_2rrA8ICOAwcm86-R08zdB6G_6KH0Hmh0", false},
+		{"S256 wrong length", s256PKCEMethod{}, "tooshort", true},
+		{"S512 valid length", s512PKCEMethod{}, "UjIB6Q3utVMTod8oURS5C7vaOK0KOMqAkmXyv8X7n597-zFIv0e3_LAvXMqksXEq-9EnGkwCImioO0t-M3Bq-g", false},
+		{"S512 wrong length", s512PKCEMethod{}, "tooshort", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.method.ValidateChallenge(tt.challenge)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("%s.ValidateChallenge(%q) error = %v, wantErr %v", tt.method.Name(), tt.challenge, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package validation
+
+import "testing"
+
+func TestMatchRedirectURI(t *testing.T) {
+	tests := []struct {
+		name       string
+		registered string
+		presented  string
+		want       bool
+	}{
+		{"exact match", "https://example.com/callback", "https://example.com/callback", true},
+		{"different path", "https://example.com/callback", "https://example.com/other", false},
+		{"omitted port wildcard, 127.0.0.1", "http://127.0.0.1/callback", "http://127.0.0.1:54321/callback", true},
+		{"zero port wildcard, 127.0.0.1", "http://127.0.0.1:0/callback", "http://127.0.0.1:54321/callback", true},
+		{"omitted port wildcard, localhost", "http://localhost/callback", "http://localhost:8080/callback", true},
+		{"omitted port wildcard, ::1", "http://[::1]/callback", "http://[::1]:9999/callback", true},
+		{"wildcard does not change host", "http://127.0.0.1/callback", "http://evil.example.com:54321/callback", false},
+		{"wildcard does not change path", "http://127.0.0.1/callback", "http://127.0.0.1:54321/other", false},
+		{"non-loopback registered port is not wildcarded", "http://example.com:8080/callback", "http://example.com:9090/callback", false},
+		{"fixed port on loopback must match exactly", "http://127.0.0.1:8080/callback", "http://127.0.0.1:9090/callback", false},
+		{"fixed port on loopback matches", "http://127.0.0.1:8080/callback", "http://127.0.0.1:8080/callback", true},
+		{"malformed presented", "http://127.0.0.1/callback", "://not a url", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MatchRedirectURI(tt.registered, tt.presented); got != tt.want {
+				t.Errorf("MatchRedirectURI(%q, %q) = %v, want %v", tt.registered, tt.presented, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateClientRedirectURI(t *testing.T) {
+	tests := []struct {
+		name                   string
+		redirect               string
+		allowInsecureRedirects bool
+		wantOK                 bool
+	}{
+		{"https always allowed", "https://example.com/callback", false, true},
+		{"custom reversed-DNS scheme allowed", "com.example.app:/callback", false, true},
+		{"custom scheme with dot but not reversed-DNS", "not.valid..scheme:/callback", false, false},
+		{"loopback http allowed without opt-in", "http://127.0.0.1:12345/callback", false, true},
+		{"localhost http allowed without opt-in", "http://localhost/callback", false, true},
+		{"non-loopback http rejected without opt-in", "http://example.com/callback", false, false},
+		{"non-loopback http allowed with opt-in", "http://example.com/callback", true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, msg := ValidateClientRedirectURI(tt.redirect, tt.allowInsecureRedirects)
+			if ok != tt.wantOK {
+				t.Errorf("ValidateClientRedirectURI(%q, %v) = (%v, %q), want ok %v", tt.redirect, tt.allowInsecureRedirects, ok, msg, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package validation
+
+import (
+	"fmt"
+	"net/url"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"k8s.io/kubernetes/pkg/util/validation/field"
+
+	"github.com/openshift/origin/pkg/oauth/api"
+)
+
+// allowedRequestObjectSigningAlgs are the JWS algorithms a client may
+// register for signed request objects. "none" is deliberately excluded.
+var allowedRequestObjectSigningAlgs = map[string]bool{
+	"RS256": true,
+	"ES256": true,
+	"PS256": true,
+}
+
+// validateClientJAR validates the RFC 9101 request-object fields of an
+// OAuthClient: RequestObjectSigningAlg, JWKSURI, and JWKS.
+func validateClientJAR(client *api.OAuthClient, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(client.RequestObjectSigningAlg) > 0 && !allowedRequestObjectSigningAlgs[client.RequestObjectSigningAlg] {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("requestObjectSigningAlg"), client.RequestObjectSigningAlg, []string{"RS256", "ES256", "PS256"}))
+	}
+
+	if len(client.JWKSURI) > 0 && len(client.JWKS) > 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath, client.Name, "at most one of jwksURI, jwks may be set"))
+		return allErrs
+	}
+
+	if len(client.JWKSURI) > 0 {
+		u, err := url.Parse(client.JWKSURI)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("jwksURI"), client.JWKSURI, err.Error()))
+		} else if u.Scheme != "https" || len(u.Host) == 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("jwksURI"), client.JWKSURI, "must be an absolute https URL"))
+		}
+	}
+
+	if len(client.JWKS) > 0 {
+		allErrs = append(allErrs, validateJWKS(client.JWKS, fldPath.Child("jwks"))...)
+	}
+
+	// ValidateAuthorizeRequestObject can only verify a request object's
+	// signature against keys it already has in hand; it does not fetch
+	// jwksURI. Until that fetch is implemented, a client that registers
+	// for signed request objects must publish its keys inline.
+	if len(client.RequestObjectSigningAlg) > 0 && len(client.JWKS) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("jwks"), "required when requestObjectSigningAlg is set; jwksURI is not yet fetched for request-object verification"))
+	}
+
+	return allErrs
+}
+
+// AuthorizeParams is the set of authorize-endpoint parameters that can be
+// conveyed either as plain query parameters or, per RFC 9101, inside a
+// signed request object.
+type AuthorizeParams struct {
+	ClientID            string
+	RedirectURI         string
+	ResponseType        string
+	Scope               string
+	State               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+}
+
+// ValidateAuthorizeRequestObject parses and verifies the JWT carried in an
+// authorize request's request= parameter: it resolves the issuing client via
+// clientLookup, verifies the JWT's signature against that client's JWKS,
+// and checks aud/exp/iat/nbf. expectedAudience must be the authorization
+// server's own issuer/audience identifier; the request object's aud claim
+// is rejected unless it matches exactly, so a request object forged or
+// replayed for a different audience is never accepted. The authorize
+// endpoint treats the returned AuthorizeParams as authoritative over any
+// duplicated query parameters, so a value altered in the (unsigned) query
+// string cannot take effect.
+func ValidateAuthorizeRequestObject(clientLookup func(clientID string) *api.OAuthClient, raw, expectedAudience string) (*AuthorizeParams, field.ErrorList) {
+	allErrs := field.ErrorList{}
+	fldPath := field.NewPath("request")
+
+	unverified, _, err := new(jwt.Parser).ParseUnverified(raw, jwt.MapClaims{})
+	if err != nil {
+		return nil, append(allErrs, field.Invalid(fldPath, raw, fmt.Sprintf("must be a well-formed JWT: %v", err)))
+	}
+	unverifiedClaims, ok := unverified.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, append(allErrs, field.Invalid(fldPath, raw, "must have JSON object claims"))
+	}
+	clientID, _ := unverifiedClaims["client_id"].(string)
+	if len(clientID) == 0 {
+		return nil, append(allErrs, field.Invalid(fldPath, raw, "must have a client_id claim"))
+	}
+
+	client := clientLookup(clientID)
+	if client == nil {
+		return nil, append(allErrs, field.Invalid(fldPath.Child("client_id"), clientID, "does not reference a known client"))
+	}
+	if len(client.RequestObjectSigningAlg) == 0 {
+		return nil, append(allErrs, field.Invalid(fldPath.Child("client_id"), clientID, "client is not registered for signed request objects"))
+	}
+
+	// validateClientJAR requires inline JWKS whenever RequestObjectSigningAlg
+	// is set, so a client reaching this point always has usable keys here.
+	if len(client.JWKS) == 0 {
+		return nil, append(allErrs, field.Invalid(fldPath.Child("client_id"), clientID, "client has no usable jwks"))
+	}
+	keys, err := parseJWKSKeys(client.JWKS)
+	if err != nil {
+		return nil, append(allErrs, field.Invalid(fldPath, clientID, err.Error()))
+	}
+
+	verified, err := new(jwt.Parser).Parse(raw, func(t *jwt.Token) (interface{}, error) {
+		if t.Method.Alg() != client.RequestObjectSigningAlg {
+			return nil, fmt.Errorf("alg %q does not match client's registered %q", t.Method.Alg(), client.RequestObjectSigningAlg)
+		}
+		kid, _ := t.Header["kid"].(string)
+		key, ok := keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("no key found for kid %q", kid)
+		}
+		return key, nil
+	})
+	if err != nil {
+		return nil, append(allErrs, field.Invalid(fldPath, raw, fmt.Sprintf("signature verification failed: %v", err)))
+	}
+
+	verifiedClaims, ok := verified.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, append(allErrs, field.Invalid(fldPath, raw, "must have JSON object claims"))
+	}
+	// jwt-go's Valid only checks exp/iat/nbf when present, but RFC 9101
+	// request objects must be time-bound, so exp is mandatory here.
+	if _, ok := verifiedClaims["exp"]; !ok {
+		return nil, append(allErrs, field.Required(fldPath, "request object must have an exp claim"))
+	}
+	if err := verifiedClaims.Valid(); err != nil {
+		return nil, append(allErrs, field.Invalid(fldPath, raw, fmt.Sprintf("exp/iat/nbf validation failed: %v", err)))
+	}
+	aud, _ := verifiedClaims["aud"].(string)
+	if len(aud) == 0 {
+		return nil, append(allErrs, field.Invalid(fldPath, raw, "must have an aud claim identifying the authorization server"))
+	}
+	if aud != expectedAudience {
+		return nil, append(allErrs, field.Invalid(fldPath, raw, "aud claim does not match the authorization server's audience identifier"))
+	}
+
+	params := &AuthorizeParams{ClientID: clientID}
+	if v, ok := verifiedClaims["redirect_uri"].(string); ok {
+		params.RedirectURI = v
+	}
+	if v, ok := verifiedClaims["response_type"].(string); ok {
+		params.ResponseType = v
+	}
+	if v, ok := verifiedClaims["scope"].(string); ok {
+		params.Scope = v
+	}
+	if v, ok := verifiedClaims["state"].(string); ok {
+		params.State = v
+	}
+	if v, ok := verifiedClaims["code_challenge"].(string); ok {
+		params.CodeChallenge = v
+	}
+	if v, ok := verifiedClaims["code_challenge_method"].(string); ok {
+		params.CodeChallengeMethod = v
+	}
+
+	return params, allErrs
+}
@@ -0,0 +1,183 @@
+package validation
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+
+	"github.com/openshift/origin/pkg/oauth/api"
+)
+
+const requestObjectTestKid = "test-key-1"
+
+func newRequestObjectTestClient(t *testing.T, key *rsa.PrivateKey) *api.OAuthClient {
+	t.Helper()
+
+	jwks := jsonWebKeySet{
+		Keys: []jsonWebKey{
+			{
+				Kty: "RSA",
+				Kid: requestObjectTestKid,
+				Use: "sig",
+				N:   base64.RawURLEncoding.EncodeToString(key.PublicKey.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.PublicKey.E)).Bytes()),
+			},
+		},
+	}
+	raw, err := json.Marshal(jwks)
+	if err != nil {
+		t.Fatalf("marshal JWKS: %v", err)
+	}
+
+	client := &api.OAuthClient{
+		RequestObjectSigningAlg: "RS256",
+		JWKS:                    string(raw),
+	}
+	client.Name = "test-client"
+	return client
+}
+
+// signRequestObject builds and signs a JWT using claims, overridden by the
+// entries in override, deleting any key whose override value is nil.
+func signRequestObject(t *testing.T, key *rsa.PrivateKey, method jwt.SigningMethod, kid string, override map[string]interface{}) string {
+	t.Helper()
+
+	claims := jwt.MapClaims{
+		"client_id":     "test-client",
+		"aud":           "https://issuer.example.com",
+		"response_type": "code",
+		"redirect_uri":  "https://client.example.com/callback",
+		"scope":         "user:info",
+		"state":         "xyz",
+		"exp":           float64(time.Now().Add(time.Hour).Unix()),
+		"iat":           float64(time.Now().Unix()),
+	}
+	for k, v := range override {
+		if v == nil {
+			delete(claims, k)
+			continue
+		}
+		claims[k] = v
+	}
+
+	token := jwt.NewWithClaims(method, claims)
+	if len(kid) > 0 {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("sign request object: %v", err)
+	}
+	return signed
+}
+
+func TestValidateAuthorizeRequestObject(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate RSA key: %v", err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate second RSA key: %v", err)
+	}
+
+	client := newRequestObjectTestClient(t, key)
+	clientLookup := func(clientID string) *api.OAuthClient {
+		if clientID == client.Name {
+			return client
+		}
+		return nil
+	}
+	const expectedAudience = "https://issuer.example.com"
+
+	tests := []struct {
+		name      string
+		raw       string
+		wantValid bool
+	}{
+		{
+			name:      "valid request object",
+			raw:       signRequestObject(t, key, jwt.SigningMethodRS256, requestObjectTestKid, nil),
+			wantValid: true,
+		},
+		{
+			name:      "wrong aud rejected",
+			raw:       signRequestObject(t, key, jwt.SigningMethodRS256, requestObjectTestKid, map[string]interface{}{"aud": "https://attacker.example.com"}),
+			wantValid: false,
+		},
+		{
+			name:      "missing aud rejected",
+			raw:       signRequestObject(t, key, jwt.SigningMethodRS256, requestObjectTestKid, map[string]interface{}{"aud": nil}),
+			wantValid: false,
+		},
+		{
+			name:      "expired token rejected",
+			raw:       signRequestObject(t, key, jwt.SigningMethodRS256, requestObjectTestKid, map[string]interface{}{"exp": float64(time.Now().Add(-time.Hour).Unix())}),
+			wantValid: false,
+		},
+		{
+			name:      "missing exp rejected",
+			raw:       signRequestObject(t, key, jwt.SigningMethodRS256, requestObjectTestKid, map[string]interface{}{"exp": nil}),
+			wantValid: false,
+		},
+		{
+			name:      "signature from wrong key rejected",
+			raw:       signRequestObject(t, otherKey, jwt.SigningMethodRS256, requestObjectTestKid, nil),
+			wantValid: false,
+		},
+		{
+			name:      "unknown kid rejected",
+			raw:       signRequestObject(t, key, jwt.SigningMethodRS256, "no-such-kid", nil),
+			wantValid: false,
+		},
+		{
+			name:      "unknown client_id rejected",
+			raw:       signRequestObject(t, key, jwt.SigningMethodRS256, requestObjectTestKid, map[string]interface{}{"client_id": "no-such-client"}),
+			wantValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, errs := ValidateAuthorizeRequestObject(clientLookup, tt.raw, expectedAudience)
+			if tt.wantValid {
+				if len(errs) != 0 {
+					t.Fatalf("unexpected errors: %v", errs)
+				}
+				if params == nil || params.ClientID != client.Name {
+					t.Fatalf("expected params for client %q, got %+v", client.Name, params)
+				}
+			} else if len(errs) == 0 {
+				t.Fatalf("expected validation errors, got none (params=%+v)", params)
+			}
+		})
+	}
+}
+
+func TestValidateAuthorizeRequestObjectWrongAlg(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate RSA key: %v", err)
+	}
+	client := newRequestObjectTestClient(t, key)
+	clientLookup := func(clientID string) *api.OAuthClient {
+		if clientID == client.Name {
+			return client
+		}
+		return nil
+	}
+
+	// client.RequestObjectSigningAlg is RS256; signing with PS256 instead
+	// (a different, also-allowed alg) must still be rejected as a mismatch.
+	raw := signRequestObject(t, key, jwt.SigningMethodPS256, requestObjectTestKid, nil)
+
+	if _, errs := ValidateAuthorizeRequestObject(clientLookup, raw, "https://issuer.example.com"); len(errs) == 0 {
+		t.Fatal("expected an alg-mismatch error, got none")
+	}
+}
@@ -13,25 +13,23 @@ import (
 	oapi "github.com/openshift/origin/pkg/api"
 	authorizerscopes "github.com/openshift/origin/pkg/authorization/authorizer/scope"
 	"github.com/openshift/origin/pkg/oauth/api"
+	"github.com/openshift/origin/pkg/oauth/tokenhash"
 	uservalidation "github.com/openshift/origin/pkg/user/api/validation"
 )
 
 const MinTokenLength = 32
 
-// PKCE [RFC7636] code challenge methods supported
-// https://tools.ietf.org/html/rfc7636#section-4.3
-const (
-	codeChallengeMethodPlain  = "plain"
-	codeChallengeMethodSHA256 = "S256"
-)
-
-var CodeChallengeMethodsSupported = []string{codeChallengeMethodPlain, codeChallengeMethodSHA256}
-
 func ValidateTokenName(name string, prefix bool) []string {
 	if reasons := oapi.MinimalNameRequirements(name, prefix); len(reasons) != 0 {
 		return reasons
 	}
 
+	// sha256~ prefixed names store only a hash of the bearer token's secret
+	// portion, so they are exempt from the legacy random-name length check.
+	if tokenhash.IsSHA256PrefixedName(name) {
+		return nil
+	}
+
 	if len(name) < MinTokenLength {
 		return []string{fmt.Sprintf("must be at least %d characters long", MinTokenLength)}
 	}
@@ -61,6 +59,77 @@ func ValidateRedirectURI(redirect string) (bool, string) {
 	return true, ""
 }
 
+// customSchemeRegex matches a reversed-DNS custom URI scheme, e.g.
+// "com.example.app", as used by native-app redirects per RFC 8252 section 7.1.
+var customSchemeRegex = regexp.MustCompile(`^[a-zA-Z0-9-]+(\.[a-zA-Z0-9-]+)+$`)
+
+// isLoopbackHost returns true for the loopback hostnames RFC 8252 section
+// 7.3 allows a native app to redirect to on an ephemeral port.
+func isLoopbackHost(host string) bool {
+	switch host {
+	case "127.0.0.1", "::1", "localhost":
+		return true
+	default:
+		return false
+	}
+}
+
+// ValidateClientRedirectURI validates a single entry of OAuthClient.RedirectURIs.
+// In addition to the generic checks ValidateRedirectURI performs, it accepts
+// the two native-app redirect classes defined by RFC 8252: reversed-DNS
+// custom schemes (section 7.1), and loopback http redirects (section 7.3),
+// whose registered port may be omitted or "0" to allow any ephemeral port
+// at authorize time. Plain http to a non-loopback host is rejected unless
+// allowInsecureRedirects is set.
+func ValidateClientRedirectURI(redirect string, allowInsecureRedirects bool) (bool, string) {
+	if ok, msg := ValidateRedirectURI(redirect); !ok {
+		return false, msg
+	}
+	if len(redirect) == 0 {
+		return true, ""
+	}
+
+	u, err := url.Parse(redirect)
+	if err != nil {
+		return false, err.Error()
+	}
+
+	if strings.Contains(u.Scheme, ".") && !customSchemeRegex.MatchString(u.Scheme) {
+		return false, "a custom scheme containing '.' must be a reversed-DNS label sequence, e.g. com.example.app"
+	}
+
+	if u.Scheme == "http" && !isLoopbackHost(u.Hostname()) && !allowInsecureRedirects {
+		return false, "must use https, a loopback address (127.0.0.1, ::1, localhost), or set allowInsecureRedirects"
+	}
+
+	return true, ""
+}
+
+// MatchRedirectURI reports whether presented, the redirect_uri a client
+// supplied at authorize time, satisfies registered, one of the client's
+// registered RedirectURIs. This is exact string equality, except when
+// registered is a loopback redirect with an omitted or "0" port, in which
+// case any port presented by the client is accepted, per RFC 8252 section 7.3.
+func MatchRedirectURI(registered, presented string) bool {
+	if registered == presented {
+		return true
+	}
+
+	ru, err := url.Parse(registered)
+	if err != nil || !isLoopbackHost(ru.Hostname()) || (ru.Port() != "" && ru.Port() != "0") {
+		return false
+	}
+
+	pu, err := url.Parse(presented)
+	if err != nil || pu.Hostname() != ru.Hostname() {
+		return false
+	}
+
+	effective := *ru
+	effective.Host = pu.Host
+	return effective.String() == pu.String()
+}
+
 func ValidateAccessToken(accessToken *api.OAuthAccessToken) field.ErrorList {
 	allErrs := validation.ValidateObjectMeta(&accessToken.ObjectMeta, false, ValidateTokenName, field.NewPath("metadata"))
 	allErrs = append(allErrs, ValidateClientNameField(accessToken.ClientName, field.NewPath("clientName"))...)
@@ -84,8 +153,6 @@ func ValidateAccessTokenUpdate(newToken, oldToken *api.OAuthAccessToken) field.E
 	return append(allErrs, validation.ValidateImmutableField(newToken, &copied, field.NewPath(""))...)
 }
 
-var codeChallengeRegex = regexp.MustCompile("^[a-zA-Z0-9._~-]{43,128}$")
-
 func ValidateAuthorizeToken(authorizeToken *api.OAuthAuthorizeToken) field.ErrorList {
 	allErrs := validation.ValidateObjectMeta(&authorizeToken.ObjectMeta, false, ValidateTokenName, field.NewPath("metadata"))
 	allErrs = append(allErrs, ValidateClientNameField(authorizeToken.ClientName, field.NewPath("clientName"))...)
@@ -100,20 +167,22 @@ func ValidateAuthorizeToken(authorizeToken *api.OAuthAuthorizeToken) field.Error
 	}
 
 	if len(authorizeToken.CodeChallenge) > 0 || len(authorizeToken.CodeChallengeMethod) > 0 {
-		switch {
-		case len(authorizeToken.CodeChallenge) == 0:
+		if len(authorizeToken.CodeChallenge) == 0 {
 			allErrs = append(allErrs, field.Required(field.NewPath("codeChallenge"), "required if codeChallengeMethod is specified"))
-		case !codeChallengeRegex.MatchString(authorizeToken.CodeChallenge):
-			allErrs = append(allErrs, field.Invalid(field.NewPath("codeChallenge"), authorizeToken.CodeChallenge, "must be 43-128 characters [a-zA-Z0-9.~_-]"))
 		}
 
-		switch authorizeToken.CodeChallengeMethod {
-		case "":
+		switch {
+		case len(authorizeToken.CodeChallengeMethod) == 0:
 			allErrs = append(allErrs, field.Required(field.NewPath("codeChallengeMethod"), "required if codeChallenge is specified"))
-		case codeChallengeMethodPlain, codeChallengeMethodSHA256:
-			// no-op, good
 		default:
-			allErrs = append(allErrs, field.NotSupported(field.NewPath("codeChallengeMethod"), authorizeToken.CodeChallengeMethod, CodeChallengeMethodsSupported))
+			method, ok := GetPKCEMethod(authorizeToken.CodeChallengeMethod)
+			if !ok {
+				allErrs = append(allErrs, field.NotSupported(field.NewPath("codeChallengeMethod"), authorizeToken.CodeChallengeMethod, CodeChallengeMethodsSupported()))
+			} else if len(authorizeToken.CodeChallenge) > 0 {
+				if err := method.ValidateChallenge(authorizeToken.CodeChallenge); err != nil {
+					allErrs = append(allErrs, field.Invalid(field.NewPath("codeChallenge"), authorizeToken.CodeChallenge, err.Error()))
+				}
+			}
 		}
 	}
 
@@ -130,7 +199,7 @@ func ValidateAuthorizeTokenUpdate(newToken, oldToken *api.OAuthAuthorizeToken) f
 func ValidateClient(client *api.OAuthClient) field.ErrorList {
 	allErrs := validation.ValidateObjectMeta(&client.ObjectMeta, false, validation.NameIsDNSSubdomain, field.NewPath("metadata"))
 	for i, redirect := range client.RedirectURIs {
-		if ok, msg := ValidateRedirectURI(redirect); !ok {
+		if ok, msg := ValidateClientRedirectURI(redirect, client.AllowInsecureRedirects); !ok {
 			allErrs = append(allErrs, field.Invalid(field.NewPath("redirectURIs").Index(i), redirect, msg))
 		}
 	}
@@ -139,6 +208,23 @@ func ValidateClient(client *api.OAuthClient) field.ErrorList {
 		allErrs = append(allErrs, ValidateScopeRestriction(restriction, field.NewPath("scopeRestrictions").Index(i))...)
 	}
 
+	allErrs = append(allErrs, validateClientJAR(client, field.NewPath(""))...)
+
+	if len(allErrs) == 0 {
+		// Compiling here, in addition to the per-restriction regex check in
+		// ValidateScopeRestriction, confirms that the restrictions build the
+		// ClientScopeMatcher used for token-issue scope checks. Each Regex
+		// restriction is compiled on its own, not combined with the others.
+		// Validation runs on speculative requests too (dry-run,
+		// admission re-validation) that never persist the object, so the
+		// compiled result is discarded rather than cached here; a future
+		// create/update strategy should compile and store it itself once the
+		// object is durably persisted.
+		if _, err := authorizerscopes.CompileClientScopeMatcher(client.ScopeRestrictions); err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("scopeRestrictions"), client.ScopeRestrictions, err.Error()))
+		}
+	}
+
 	return allErrs
 }
 
@@ -152,8 +238,11 @@ func ValidateScopeRestriction(restriction api.ScopeRestriction, fldPath *field.P
 	if restriction.ClusterRole != nil {
 		specifiers = specifiers + 1
 	}
+	if len(restriction.Regex) > 0 {
+		specifiers = specifiers + 1
+	}
 	if specifiers != 1 {
-		allErrs = append(allErrs, field.Invalid(fldPath, restriction, "exactly one of literals, clusterRole is required"))
+		allErrs = append(allErrs, field.Invalid(fldPath, restriction, "exactly one of literals, clusterRole, regex is required"))
 		return allErrs
 	}
 
@@ -172,6 +261,11 @@ func ValidateScopeRestriction(restriction api.ScopeRestriction, fldPath *field.P
 		if len(restriction.ClusterRole.Namespaces) == 0 {
 			allErrs = append(allErrs, field.Required(fldPath.Child("clusterRole", "namespaces"), "won't match anything"))
 		}
+
+	case len(restriction.Regex) > 0:
+		if _, err := regexp.Compile(restriction.Regex); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("regex"), restriction.Regex, fmt.Sprintf("must be a valid RE2 regular expression: %v", err)))
+		}
 	}
 
 	return allErrs
@@ -290,14 +384,23 @@ func ValidateScopes(scopes []string, fldPath *field.Path) field.ErrorList {
 			}
 
 			found = true
-			if err := evaluator.Validate(scope); err != nil {
+			parsed, err := evaluator.Parse(scope)
+			if err != nil {
 				allErrs = append(allErrs, field.Invalid(fldPath.Index(i), scope, err.Error()))
 				break
 			}
+			if err := evaluator.Validate(scope); err != nil {
+				allErrs = append(allErrs, field.Invalid(fldPath.Index(i), scope, fmt.Sprintf("%s: %v", parsed.Handler, err)))
+				break
+			}
 		}
 
 		if !found {
-			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), scope, "no scope handler found"))
+			handler := scope
+			if idx := strings.IndexByte(scope, ':'); idx >= 0 {
+				handler = scope[:idx]
+			}
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), scope, fmt.Sprintf("no scope handler registered for %q", handler)))
 		}
 	}
 
@@ -0,0 +1,53 @@
+// Package tokenhash computes the storage name for sha256~ prefixed OAuth
+// bearer tokens, so that the secret portion handed to clients is never
+// persisted in etcd. Callers on the read path (the registry storage layer,
+// and the bootstrap/service-account token authenticators) must run an
+// incoming bearer token through HashToken before looking it up; this
+// checkout has none of those callers, so only this package and the
+// validation-layer name-shape check exist here.
+package tokenhash
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// Prefix marks a bearer token (and the OAuthAccessToken/OAuthAuthorizeToken
+// name derived from it) as sha256-hashed at rest rather than stored as the
+// plaintext secret.
+const Prefix = "sha256~"
+
+// HashToken accepts the plaintext bearer token that is handed to the client
+// and returns the name under which the corresponding OAuthAccessToken or
+// OAuthAuthorizeToken should be persisted. Tokens without the Prefix are
+// returned unchanged, so legacy plaintext-named tokens keep working.
+func HashToken(publicToken string) (string, error) {
+	if !strings.HasPrefix(publicToken, Prefix) {
+		return publicToken, nil
+	}
+
+	secret := strings.TrimPrefix(publicToken, Prefix)
+	if len(secret) == 0 {
+		return "", fmt.Errorf("token has no secret content after the %q prefix", Prefix)
+	}
+
+	sum := sha256.Sum256([]byte(secret))
+	return Prefix + base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
+
+// IsSHA256PrefixedName returns true if name has the shape produced by
+// HashToken: the Prefix followed by the unpadded base64url encoding of a
+// 32-byte sha256 sum.
+func IsSHA256PrefixedName(name string) bool {
+	if !strings.HasPrefix(name, Prefix) {
+		return false
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(name, Prefix))
+	if err != nil {
+		return false
+	}
+	return len(decoded) == sha256.Size
+}